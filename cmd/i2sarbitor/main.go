@@ -11,9 +11,53 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/zditech/i2sarbitor/internal/api"
 	"github.com/zditech/i2sarbitor/internal/arbiter"
+	"github.com/zditech/i2sarbitor/internal/arbiter/discovery"
+	"github.com/zditech/i2sarbitor/internal/arbiter/driver"
+	"github.com/zditech/i2sarbitor/internal/audit"
 	"github.com/zditech/i2sarbitor/internal/config"
 )
 
+const configPath = "/etc/i2sarbitor/i2sarbitor.yaml"
+
+// loadDrivers registers the out-of-process drivers configured under
+// cfg.Drivers, so Services can reference them by name before the arbiter
+// resolves any driver. Failures are logged and skipped rather than
+// treated as fatal, so one bad plugin or RPC driver doesn't keep the rest
+// of the service set from starting.
+func loadDrivers(cfg *config.Config) {
+	for _, path := range cfg.Drivers.Plugins {
+		if err := driver.LoadPlugin(path); err != nil {
+			log.Warn().Err(err).Str("path", path).Msg("failed to load driver plugin")
+		}
+	}
+
+	for _, rpcCfg := range cfg.Drivers.RPC {
+		if _, err := driver.NewRPCDriver(rpcCfg.Name, rpcCfg.Command, rpcCfg.Args...); err != nil {
+			log.Warn().Err(err).Str("name", rpcCfg.Name).Str("command", rpcCfg.Command).Msg("failed to start RPC driver")
+		}
+	}
+}
+
+// newDiscovery builds the Discovery backend selected by cfg.Discovery, or
+// nil if the static Services list should be the only source.
+func newDiscovery(cfg *config.Config) discovery.Discovery {
+	switch cfg.Discovery.Backend {
+	case "file":
+		return discovery.NewFileDiscovery(configPath)
+	case "mdns":
+		return discovery.NewMDNSDiscovery(cfg.Discovery.MDNS.ServiceType)
+	case "consul":
+		d, err := discovery.NewConsulDiscovery(cfg.Discovery.Consul.Address, cfg.Discovery.Consul.Tag)
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to create consul discovery, falling back to static services")
+			return nil
+		}
+		return d
+	default:
+		return nil
+	}
+}
+
 func main() {
 	// Setup logging
 	log.Logger = zerolog.New(zerolog.ConsoleWriter{
@@ -24,20 +68,41 @@ func main() {
 	log.Info().Msg("i2sArbitor starting...")
 
 	// Load configuration
-	cfg, err := config.Load("/etc/i2sarbitor/i2sarbitor.yaml")
+	cfg, err := config.Load(configPath)
 	if err != nil {
 		log.Warn().Err(err).Msg("failed to load config, using defaults")
 		cfg = config.Default()
 	}
 
+	// Load out-of-process drivers before the arbiter resolves any Service's
+	// driver, so drivers.plugins/drivers.rpc entries are usable by name.
+	loadDrivers(cfg)
+
 	// Create arbiter
 	arb := arbiter.New(cfg)
 
+	// Start the audit log, if configured
+	if sink := audit.NewSink(cfg.Audit, arb); sink != nil {
+		defer sink.Close()
+	}
+
 	// Start monitoring services
 	arb.StartMonitoring()
 
+	// Start service discovery, if configured
+	discoveryCtx, cancelDiscovery := context.WithCancel(context.Background())
+	defer cancelDiscovery()
+	if d := newDiscovery(cfg); d != nil {
+		log.Info().Str("backend", cfg.Discovery.Backend).Msg("starting service discovery")
+		arb.WatchDiscovery(discoveryCtx, d)
+	}
+
+	// shutdownCh is signalled either by an OS signal or by a POST to
+	// /api/v1/service/stop, so both paths run the same shutdown sequence.
+	shutdownCh := make(chan struct{}, 1)
+
 	// Create and start API server
-	server := api.NewServer(cfg, arb)
+	server := api.NewServer(cfg, arb, configPath, shutdownCh)
 	go func() {
 		if err := server.Start(); err != nil {
 			log.Fatal().Err(err).Msg("failed to start API server")
@@ -46,13 +111,25 @@ func main() {
 
 	log.Info().Int("port", cfg.APIPort).Msg("i2sArbitor running")
 
-	// Wait for shutdown signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	go func() {
+		<-quit
+		select {
+		case shutdownCh <- struct{}{}:
+		default:
+		}
+	}()
+
+	// Wait for shutdown signal
+	<-shutdownCh
 
 	log.Info().Msg("shutting down...")
 
+	if err := arb.DeactivateAll(); err != nil {
+		log.Warn().Err(err).Msg("failed to deactivate all services during shutdown")
+	}
+
 	// Graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -0,0 +1,84 @@
+// Command i2sarbitorctl is a small CLI for the i2sArbitor service control
+// API: stop, restart (reload config) and status.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+func main() {
+	addr := flag.String("addr", "http://localhost:8090", "i2sArbitor API base URL")
+	token := flag.String("token", os.Getenv("I2SARBITORCTL_TOKEN"), "bearer token for the service control API")
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: i2sarbitorctl [-addr url] [-token token] <stop|restart|status>")
+		os.Exit(2)
+	}
+
+	method, path, err := commandRoute(args[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if err := run(*addr, *token, method, path); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+// commandRoute maps a CLI subcommand to its service control endpoint.
+func commandRoute(cmd string) (method, path string, err error) {
+	switch cmd {
+	case "stop":
+		return http.MethodPost, "/api/v1/service/stop", nil
+	case "restart":
+		return http.MethodPost, "/api/v1/service/restart", nil
+	case "status":
+		return http.MethodGet, "/api/v1/service/status", nil
+	default:
+		return "", "", fmt.Errorf("unknown command: %s", cmd)
+	}
+}
+
+// run issues the request and pretty-prints the JSON response.
+func run(addr, token, method, path string) error {
+	req, err := http.NewRequest(method, addr+path, nil)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s: %s", resp.Status, body)
+	}
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		fmt.Println(string(body))
+		return nil
+	}
+	pretty, _ := json.MarshalIndent(parsed, "", "  ")
+	fmt.Println(string(pretty))
+	return nil
+}
@@ -2,34 +2,59 @@ package api
 
 import (
 	"context"
+	"crypto/sha256"
 	"embed"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/fs"
 	"net/http"
+	"os"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog/log"
 	"github.com/zditech/i2sarbitor/internal/arbiter"
 	"github.com/zditech/i2sarbitor/internal/config"
 )
 
+// eventHeartbeatInterval is how often SSE clients receive a keep-alive
+// comment in the absence of real events.
+const eventHeartbeatInterval = 15 * time.Second
+
+// wsUpgrader upgrades /api/v1/ws connections. Origin checking is left to
+// reverse proxies/firewalls, matching the rest of the API's open CORS policy.
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
 //go:embed web
 var webFS embed.FS
 
 // Server handles HTTP requests
 type Server struct {
-	cfg     *config.Config
-	arbiter *arbiter.Arbiter
-	server  *http.Server
+	cfg        *config.Config
+	arbiter    *arbiter.Arbiter
+	server     *http.Server
+	configPath string
+	shutdownCh chan<- struct{}
 }
 
-// NewServer creates a new API server
-func NewServer(cfg *config.Config, arb *arbiter.Arbiter) *Server {
+// NewServer creates a new API server. configPath is re-read on
+// /api/v1/service/restart, and shutdownCh is signalled (never closed, so
+// the call is safe from any goroutine) when /api/v1/service/stop is
+// invoked, mirroring the process's own signal handler.
+func NewServer(cfg *config.Config, arb *arbiter.Arbiter, configPath string, shutdownCh chan<- struct{}) *Server {
 	return &Server{
-		cfg:     cfg,
-		arbiter: arb,
+		cfg:        cfg,
+		arbiter:    arb,
+		configPath: configPath,
+		shutdownCh: shutdownCh,
 	}
 }
 
@@ -51,11 +76,24 @@ func (s *Server) Start() error {
 		r.Post("/services/{name}/lock", s.handleLockService)
 		r.Delete("/services/{name}/lock", s.handleUnlockService)
 		r.Post("/deactivate-all", s.handleDeactivateAll)
+		r.Get("/events", s.handleEvents)
+		r.Get("/ws", s.handleWebSocket)
+		r.Post("/mode", s.handleSetMode)
+
+		r.Route("/service", func(r chi.Router) {
+			r.Use(bearerAuthMiddleware(s.cfg.APIToken))
+			r.Post("/stop", s.handleServiceStop)
+			r.Post("/restart", s.handleServiceRestart)
+			r.Get("/status", s.handleServiceStatus)
+		})
 	})
 
 	// Health check
 	r.Get("/health", s.handleHealth)
 
+	// Prometheus metrics
+	r.Get("/metrics", promhttp.Handler().ServeHTTP)
+
 	// Static files (web UI)
 	webContent, _ := fs.Sub(webFS, "web")
 	fileServer := http.FileServer(http.FS(webContent))
@@ -94,6 +132,25 @@ func corsMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// bearerAuthMiddleware rejects requests whose Authorization header doesn't
+// carry the configured bearer token. An empty token disables auth, which
+// is only appropriate for local development.
+func bearerAuthMiddleware(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if r.Header.Get("Authorization") != "Bearer "+token {
+				writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // handleHealth returns service health
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]string{"status": "healthy"})
@@ -180,6 +237,225 @@ func (s *Server) handleDeactivateAll(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handleSetMode switches the arbiter between manual and auto arbitration
+func (s *Server) handleSetMode(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Mode string `json:"mode"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		return
+	}
+
+	if err := s.arbiter.SetMode(req.Mode); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"mode":    s.arbiter.Mode(),
+	})
+}
+
+// handleServiceStop performs an orderly shutdown: the response is sent
+// first, then the shared shutdown channel is signalled so main's signal
+// handler runs the same DeactivateAll/StopMonitoring/Shutdown sequence a
+// SIGTERM would trigger.
+func (s *Server) handleServiceStop(w http.ResponseWriter, r *http.Request) {
+	log.Info().Msg("stop requested via API")
+	writeJSON(w, http.StatusOK, map[string]interface{}{"success": true})
+
+	select {
+	case s.shutdownCh <- struct{}{}:
+	default:
+	}
+}
+
+// handleServiceRestart re-reads the config file and hot-swaps it into the
+// running Arbiter, adding/removing services and resetting the poll ticker
+// without dropping lock state for services present in both configs.
+func (s *Server) handleServiceRestart(w http.ResponseWriter, r *http.Request) {
+	cfg, err := config.Load(s.configPath)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+
+	if err := s.arbiter.ReloadConfig(cfg); err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	s.cfg = cfg
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success":  true,
+		"services": len(cfg.Services),
+	})
+}
+
+// handleServiceStatus returns aggregate health suitable for monitoring:
+// uptime, the config file in use and its hash, and per-service driver and
+// last-check age.
+func (s *Server) handleServiceStatus(w http.ResponseWriter, r *http.Request) {
+	hash, err := configFileHash(s.configPath)
+	if err != nil {
+		log.Warn().Err(err).Str("path", s.configPath).Msg("failed to hash config file")
+	}
+
+	services := s.arbiter.GetAllStatus()
+	serviceStatuses := make([]map[string]interface{}, 0, len(services))
+	for _, svc := range services {
+		serviceStatuses = append(serviceStatuses, map[string]interface{}{
+			"name":             svc.Name,
+			"driver":           svc.Driver,
+			"online":           svc.Online,
+			"locked":           svc.Locked,
+			"active":           svc.Active,
+			"last_check":       svc.LastCheck,
+			"last_check_age_s": lastCheckAgeSeconds(svc.LastCheck),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"uptime_s":       s.arbiter.Uptime().Seconds(),
+		"config_path":    s.configPath,
+		"config_hash":    hash,
+		"active_service": s.arbiter.GetActiveService(),
+		"services":       serviceStatuses,
+	})
+}
+
+// configFileHash returns the hex-encoded SHA-256 of the config file at
+// path, for operators to confirm which config a running instance loaded.
+func configFileHash(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// lastCheckAgeSeconds returns how long ago lastCheck (an RFC3339 timestamp)
+// was, or -1 if it can't be parsed (e.g. the service has never been polled).
+func lastCheckAgeSeconds(lastCheck string) float64 {
+	t, err := time.Parse(time.RFC3339, lastCheck)
+	if err != nil {
+		return -1
+	}
+	return time.Since(t).Seconds()
+}
+
+// handleEvents streams arbiter events as Server-Sent Events
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	events, cancel := s.arbiter.Subscribe()
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(eventHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(evt)
+			if err != nil {
+				log.Warn().Err(err).Msg("failed to marshal event for SSE client")
+				continue
+			}
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// wsCommand is an inbound message on the /api/v1/ws connection
+type wsCommand struct {
+	Cmd     string `json:"cmd"`
+	Service string `json:"service"`
+}
+
+// handleWebSocket streams arbiter events and accepts inbound commands over
+// a WebSocket connection.
+func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Warn().Err(err).Msg("websocket upgrade failed")
+		return
+	}
+	defer conn.Close()
+
+	events, cancel := s.arbiter.Subscribe()
+	defer cancel()
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			var cmd wsCommand
+			if err := conn.ReadJSON(&cmd); err != nil {
+				return
+			}
+			s.handleWSCommand(cmd)
+		}
+	}()
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := conn.WriteJSON(evt); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
+
+// handleWSCommand applies an inbound WebSocket command to the arbiter
+func (s *Server) handleWSCommand(cmd wsCommand) {
+	var err error
+	switch cmd.Cmd {
+	case "activate":
+		err = s.arbiter.ActivateService(cmd.Service)
+	case "lock":
+		err = s.arbiter.LockService(cmd.Service, true)
+	case "unlock":
+		err = s.arbiter.LockService(cmd.Service, false)
+	case "deactivate_all":
+		err = s.arbiter.DeactivateAll()
+	default:
+		log.Warn().Str("cmd", cmd.Cmd).Msg("unknown websocket command")
+		return
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("cmd", cmd.Cmd).Str("service", cmd.Service).Msg("websocket command failed")
+	}
+}
+
 // writeJSON writes a JSON response
 func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
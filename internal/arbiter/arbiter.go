@@ -1,16 +1,16 @@
 package arbiter
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"sync"
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"github.com/zditech/i2sarbitor/internal/arbiter/discovery"
+	"github.com/zditech/i2sarbitor/internal/arbiter/driver"
 	"github.com/zditech/i2sarbitor/internal/config"
+	"github.com/zditech/i2sarbitor/internal/metrics"
 )
 
 // ServiceStatus represents the current state of a managed service
@@ -22,51 +22,94 @@ type ServiceStatus struct {
 	Locked      bool   `json:"locked"`
 	Active      bool   `json:"active"`
 	Priority    int    `json:"priority"`
+	Driver      string `json:"driver,omitempty"`
 	LastCheck   string `json:"last_check"`
 	Error       string `json:"error,omitempty"`
 }
 
 // Arbiter manages I2S service arbitration
 type Arbiter struct {
-	cfg            *config.Config
-	services       map[string]*ServiceStatus
-	activeService  string
-	mu             sync.RWMutex
-	stopChan       chan struct{}
-	client         *http.Client
+	cfg           *config.Config
+	services      map[string]*ServiceStatus
+	drivers       map[string]driver.Driver
+	activeService string
+	mu            sync.RWMutex
+	stopChan      chan struct{}
+	reloadChan    chan struct{}
+	events        *eventHub
+	clock         Clock
+	startedAt     time.Time
+
+	// Auto arbitration hysteresis bookkeeping, keyed by service name.
+	autoWinnerSince map[string]time.Time
+	autoIdleSince   map[string]time.Time
+	autoIdleCycles  map[string]int
 }
 
 // New creates a new Arbiter instance
 func New(cfg *config.Config) *Arbiter {
 	a := &Arbiter{
-		cfg:      cfg,
-		services: make(map[string]*ServiceStatus),
-		stopChan: make(chan struct{}),
-		client: &http.Client{
-			Timeout: 2 * time.Second,
-		},
+		cfg:        cfg,
+		services:   make(map[string]*ServiceStatus),
+		drivers:    make(map[string]driver.Driver),
+		stopChan:   make(chan struct{}),
+		reloadChan: make(chan struct{}, 1),
+		events:     newEventHub(),
+		clock:      realClock{},
+		startedAt:  time.Now(),
+
+		autoWinnerSince: make(map[string]time.Time),
+		autoIdleSince:   make(map[string]time.Time),
+		autoIdleCycles:  make(map[string]int),
 	}
 
 	// Initialize service status entries
 	for _, svc := range cfg.Services {
-		a.services[svc.Name] = &ServiceStatus{
-			Name:        svc.Name,
-			DisplayName: svc.DisplayName,
-			BaseURL:     svc.BaseURL,
-			Priority:    svc.Priority,
-			Online:      false,
-			Locked:      false,
-			Active:      false,
-		}
+		a.addServiceLocked(svc)
 	}
 
 	return a
 }
 
+// addServiceLocked registers svc's status entry and resolves its driver.
+// Must be called with the mutex held, or before the Arbiter is shared.
+func (a *Arbiter) addServiceLocked(svc config.Service) {
+	status := &ServiceStatus{
+		Name:        svc.Name,
+		DisplayName: svc.DisplayName,
+		BaseURL:     svc.BaseURL,
+		Priority:    svc.Priority,
+	}
+	a.services[svc.Name] = status
+
+	d, err := resolveDriver(svc)
+	if err != nil {
+		log.Error().Err(err).Str("service", svc.Name).Str("driver", svc.Driver).Msg("failed to resolve driver, service will not be polled")
+		return
+	}
+	a.drivers[svc.Name] = d
+	status.Driver = d.Name()
+}
+
+// resolveDriver picks the driver.Driver a service should use: the
+// registered driver named by svc.Driver, or a generic-http driver (the
+// default, configured from svc.GenericHTTP) if none is named.
+func resolveDriver(svc config.Service) (driver.Driver, error) {
+	name := svc.Driver
+	if name == "" || name == "generic-http" {
+		var cfg driver.GenericHTTPConfig
+		if svc.GenericHTTP != nil {
+			cfg = *svc.GenericHTTP
+		}
+		return driver.NewGenericHTTP(cfg), nil
+	}
+	return driver.Get(name)
+}
+
 // StartMonitoring begins polling services for status
 func (a *Arbiter) StartMonitoring() {
 	go func() {
-		ticker := time.NewTicker(time.Duration(a.cfg.PollIntervalMs) * time.Millisecond)
+		ticker := time.NewTicker(a.pollInterval())
 		defer ticker.Stop()
 
 		// Initial check
@@ -76,6 +119,8 @@ func (a *Arbiter) StartMonitoring() {
 			select {
 			case <-ticker.C:
 				a.pollAllServices()
+			case <-a.reloadChan:
+				ticker.Reset(a.pollInterval())
 			case <-a.stopChan:
 				return
 			}
@@ -90,6 +135,31 @@ func (a *Arbiter) StopMonitoring() {
 	log.Info().Msg("service monitoring stopped")
 }
 
+// pollInterval returns the currently configured poll interval.
+func (a *Arbiter) pollInterval() time.Duration {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return time.Duration(a.cfg.PollIntervalMs) * time.Millisecond
+}
+
+// driverCallTimeoutLocked bounds a single Poll/SetLocked call to roughly
+// one poll cycle, so a driver with no client-side timeout of its own
+// (e.g. RPCDriver, which blocks on a subprocess reply) can't stall the
+// arbiter indefinitely while pollServiceInternal/lockServiceInternal hold
+// a.mu. Must be called with the mutex held.
+func (a *Arbiter) driverCallTimeoutLocked() time.Duration {
+	d := time.Duration(a.cfg.PollIntervalMs) * time.Millisecond
+	if d < time.Second {
+		d = time.Second
+	}
+	return d
+}
+
+// Uptime returns how long the Arbiter has been running.
+func (a *Arbiter) Uptime() time.Duration {
+	return time.Since(a.startedAt)
+}
+
 // GetAllStatus returns status of all managed services
 func (a *Arbiter) GetAllStatus() []ServiceStatus {
 	a.mu.RLock()
@@ -121,11 +191,138 @@ func (a *Arbiter) GetActiveService() string {
 	return a.activeService
 }
 
+// WatchDiscovery reconciles the managed service set against d's stream of
+// discovered services until ctx is cancelled.
+func (a *Arbiter) WatchDiscovery(ctx context.Context, d discovery.Discovery) {
+	discovered := d.Watch(ctx)
+	suppliesPriority := d.SuppliesPriority()
+	go func() {
+		for {
+			select {
+			case services, ok := <-discovered:
+				if !ok {
+					return
+				}
+				a.reconcileServices(services, suppliesPriority)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// reconcileServices updates the managed service map to match a freshly
+// discovered list. suppliesPriority must be true only if the discovery
+// backend can supply accurate Priority values; see reconcileServiceSetLocked
+// for the merge rules.
+func (a *Arbiter) reconcileServices(discovered []config.Service, suppliesPriority bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.reconcileServiceSetLocked(discovered, "discovery", suppliesPriority)
+}
+
+// ReloadConfig hot-swaps cfg into the running Arbiter: services are
+// added/updated/removed per reconcileServiceSetLocked, and the poll ticker
+// is reset in case PollIntervalMs changed. Must not be called with the
+// mutex held.
+func (a *Arbiter) ReloadConfig(cfg *config.Config) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// The static YAML config is always authoritative for Priority.
+	a.reconcileServiceSetLocked(cfg.Services, "config reload", true)
+	a.cfg = cfg
+
+	select {
+	case a.reloadChan <- struct{}{}:
+	default:
+	}
+
+	log.Info().Int("poll_interval_ms", cfg.PollIntervalMs).Msg("configuration reloaded")
+	return nil
+}
+
+// reconcileServiceSetLocked updates the managed service map to match the
+// given set: new entries are added with default state, surviving entries
+// keep their Locked/Active state (only connection details are refreshed,
+// and Priority only if suppliesPriority is true), and entries that
+// disappeared are locked first if they were unlocked, then removed.
+// suppliesPriority must be false for discovery backends that can't
+// determine a service's Priority (e.g. mDNS, Consul), so a rebrowse
+// doesn't reset an operator-assigned Priority to the zero value.
+//
+// For reason == "discovery", services is treated as additive to the
+// statically-configured Services list rather than a full replacement: an
+// empty or missing browse/lookup result never removes anything (a
+// transient network miss shouldn't tear down the managed set), and a
+// statically-configured service is never removed just because the
+// current discovery backend doesn't currently see it. Must be called
+// with the mutex held.
+func (a *Arbiter) reconcileServiceSetLocked(services []config.Service, reason string, suppliesPriority bool) {
+	if reason == "discovery" && len(services) == 0 {
+		log.Warn().Msg("discovery returned no services, skipping reconcile to avoid tearing down the managed set")
+		return
+	}
+
+	seen := make(map[string]bool, len(services))
+	for _, svc := range services {
+		seen[svc.Name] = true
+
+		existing, ok := a.services[svc.Name]
+		if !ok {
+			a.addServiceLocked(svc)
+			log.Info().Str("service", svc.Name).Str("reason", reason).Msg("service added")
+			continue
+		}
+
+		existing.BaseURL = svc.BaseURL
+		existing.DisplayName = svc.DisplayName
+		if suppliesPriority {
+			existing.Priority = svc.Priority
+		}
+	}
+
+	if reason == "discovery" {
+		for _, svc := range a.cfg.Services {
+			seen[svc.Name] = true
+		}
+	}
+
+	for name, svc := range a.services {
+		if seen[name] {
+			continue
+		}
+
+		if svc.Online && !svc.Locked {
+			if err := a.lockServiceInternal(name, true); err != nil {
+				log.Warn().Err(err).Str("service", name).Msg("failed to lock service before removal")
+			}
+		}
+
+		delete(a.services, name)
+		delete(a.drivers, name)
+		log.Info().Str("service", name).Str("reason", reason).Msg("service removed")
+	}
+}
+
+// Subscribe registers for arbiter events and returns a channel of events
+// along with a cancel function that must be called once the subscriber is
+// done to release its resources.
+func (a *Arbiter) Subscribe() (<-chan Event, func()) {
+	return a.events.subscribe()
+}
+
 // ActivateService activates a service by unlocking it and locking all others
 func (a *Arbiter) ActivateService(name string) error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
+	return a.activateServiceLocked(name, SourceAPI)
+}
 
+// activateServiceLocked is the shared implementation behind ActivateService
+// and the auto arbitration preemption logic. Must be called with the
+// mutex held.
+func (a *Arbiter) activateServiceLocked(name string, source ActivationSource) error {
 	// Verify service exists
 	target, ok := a.services[name]
 	if !ok {
@@ -136,7 +333,7 @@ func (a *Arbiter) ActivateService(name string) error {
 		return fmt.Errorf("service is offline: %s", name)
 	}
 
-	log.Info().Str("service", name).Msg("activating service")
+	log.Info().Str("service", name).Str("source", string(source)).Msg("activating service")
 
 	// Lock all other services first
 	for svcName, svc := range a.services {
@@ -154,6 +351,8 @@ func (a *Arbiter) ActivateService(name string) error {
 
 	a.activeService = name
 	log.Info().Str("service", name).Msg("service activated")
+	a.events.publish(Event{Type: EventActiveServiceChanged, Service: name, Timestamp: time.Now(), Data: map[string]string{"source": string(source)}})
+	metrics.ActivationTotal.WithLabelValues(name, string(source)).Inc()
 	return nil
 }
 
@@ -175,6 +374,7 @@ func (a *Arbiter) DeactivateAll() error {
 	}
 
 	a.activeService = ""
+	a.events.publish(Event{Type: EventActiveServiceChanged, Timestamp: time.Now()})
 	return lastErr
 }
 
@@ -207,81 +407,36 @@ func (a *Arbiter) LockService(name string, lock bool) error {
 func (a *Arbiter) lockServiceInternal(name string, lock bool) error {
 	svc := a.services[name]
 
-	// Different services have different lock APIs
-	var err error
-	switch name {
-	case "usboveri2s":
-		err = a.lockUSBOverI2S(svc.BaseURL, lock)
-	case "usbaudio":
-		err = a.lockUSBAudio(svc.BaseURL, lock)
-	default:
-		// Generic lock API (try usbAudio style first)
-		err = a.lockUSBAudio(svc.BaseURL, lock)
-	}
-
-	if err != nil {
+	d, ok := a.drivers[name]
+	if !ok {
+		err := fmt.Errorf("no driver available for service: %s", name)
 		svc.Error = err.Error()
 		return err
 	}
 
-	svc.Locked = lock
-	svc.Error = ""
-
-	if lock && a.activeService == name {
-		a.activeService = ""
-	}
-
-	return nil
-}
-
-// lockUSBOverI2S handles lock API for usbOverI2S service
-func (a *Arbiter) lockUSBOverI2S(baseURL string, lock bool) error {
-	var method string
-	if lock {
-		method = http.MethodPost
-	} else {
-		method = http.MethodDelete
-	}
-
-	req, err := http.NewRequest(method, baseURL+"/api/v1/lock", nil)
-	if err != nil {
-		return err
-	}
+	ctx, cancel := context.WithTimeout(context.Background(), a.driverCallTimeoutLocked())
+	defer cancel()
 
-	resp, err := a.client.Do(req)
-	if err != nil {
+	if err := d.SetLocked(ctx, svc.BaseURL, lock); err != nil {
+		svc.Error = err.Error()
+		metrics.LockRequestsTotal.WithLabelValues(name, "error").Inc()
 		return err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("lock request failed: %s", string(body))
-	}
-
-	return nil
-}
-
-// lockUSBAudio handles lock API for usbAudio service
-func (a *Arbiter) lockUSBAudio(baseURL string, lock bool) error {
-	payload := map[string]bool{"locked": lock}
-	body, _ := json.Marshal(payload)
-
-	req, err := http.NewRequest(http.MethodPost, baseURL+"/api/v1/lock", bytes.NewReader(body))
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Content-Type", "application/json")
+	svc.Locked = lock
+	svc.Error = ""
+	metrics.LockRequestsTotal.WithLabelValues(name, "success").Inc()
+	metrics.ServiceLocked.WithLabelValues(name).Set(boolToFloat(lock))
 
-	resp, err := a.client.Do(req)
-	if err != nil {
-		return err
+	evtType := EventServiceLocked
+	if !lock {
+		evtType = EventServiceUnlocked
 	}
-	defer resp.Body.Close()
+	a.events.publish(Event{Type: evtType, Service: name, Timestamp: time.Now()})
 
-	if resp.StatusCode != http.StatusOK {
-		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("lock request failed: %s", string(respBody))
+	if lock && a.activeService == name {
+		a.activeService = ""
+		a.events.publish(Event{Type: EventActiveServiceChanged, Timestamp: time.Now()})
 	}
 
 	return nil
@@ -296,7 +451,15 @@ func (a *Arbiter) pollAllServices() {
 		a.pollServiceInternal(name)
 	}
 
-	// Enforce single unlocked service constraint
+	if a.cfg.Arbitration.Mode == modeAuto {
+		// Auto arbitration mode owns activation decisions; it locks the
+		// other services whenever it activates a winner via
+		// activateServiceLocked.
+		a.evaluateAuto(a.clock.Now())
+	}
+
+	// Enforce single unlocked service constraint in either mode, so a
+	// service unlocked by other means is re-locked between preemptions.
 	a.enforceSingleUnlocked()
 }
 
@@ -333,6 +496,7 @@ func (a *Arbiter) enforceSingleUnlocked() {
 				}
 			}
 		}
+		metrics.ActivationTotal.WithLabelValues(keepUnlocked, string(SourceEnforce)).Inc()
 
 		// Lock all others
 		for _, name := range unlockedServices {
@@ -340,7 +504,9 @@ func (a *Arbiter) enforceSingleUnlocked() {
 				log.Info().Str("service", name).Msg("auto-locking service to enforce single unlock constraint")
 				if err := a.lockServiceInternal(name, true); err != nil {
 					log.Error().Err(err).Str("service", name).Msg("failed to auto-lock service")
+					continue
 				}
+				a.events.publish(Event{Type: EventAutoLockEnforced, Service: name, Timestamp: time.Now(), Data: map[string]string{"kept_unlocked": keepUnlocked}})
 			}
 		}
 	}
@@ -350,75 +516,86 @@ func (a *Arbiter) enforceSingleUnlocked() {
 // Must be called with mutex held
 func (a *Arbiter) pollServiceInternal(name string) {
 	svc := a.services[name]
+	wasOnline := svc.Online
 	svc.LastCheck = time.Now().Format(time.RFC3339)
 
-	// Different services have different status endpoints
-	var statusURL string
-	switch name {
-	case "usboveri2s":
-		statusURL = svc.BaseURL + "/api/v1/player/status"
-	default:
-		statusURL = svc.BaseURL + "/api/v1/status"
-	}
-
-	resp, err := a.client.Get(statusURL)
-	if err != nil {
+	d, ok := a.drivers[name]
+	if !ok {
+		err := fmt.Sprintf("no driver available for service: %s", name)
 		svc.Online = false
 		svc.Active = false
-		svc.Error = err.Error()
+		svc.Error = err
+		metrics.ServiceOnline.WithLabelValues(name).Set(0)
+		metrics.ServiceActive.WithLabelValues(name).Set(0)
+		a.notePollFailure(name, wasOnline, err)
 		return
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		svc.Online = false
-		svc.Active = false
-		svc.Error = fmt.Sprintf("status code: %d", resp.StatusCode)
-		return
-	}
+	ctx, cancel := context.WithTimeout(context.Background(), a.driverCallTimeoutLocked())
+	defer cancel()
 
-	body, err := io.ReadAll(resp.Body)
+	pollStart := time.Now()
+	result, err := d.Poll(ctx, svc.BaseURL)
+	metrics.PollDuration.WithLabelValues(name).Observe(time.Since(pollStart).Seconds())
 	if err != nil {
 		svc.Online = false
+		svc.Active = false
 		svc.Error = err.Error()
-		return
-	}
-
-	// Parse status response
-	var status map[string]interface{}
-	if err := json.Unmarshal(body, &status); err != nil {
-		svc.Online = false
-		svc.Error = err.Error()
+		metrics.ServiceOnline.WithLabelValues(name).Set(0)
+		metrics.ServiceActive.WithLabelValues(name).Set(0)
+		metrics.PollErrorsTotal.Inc()
+		a.notePollFailure(name, wasOnline, err.Error())
 		return
 	}
 
 	svc.Online = true
 	svc.Error = ""
-
-	// Handle usbOverI2S response format: {"success":true,"data":{...}}
-	if data, ok := status["data"].(map[string]interface{}); ok {
-		status = data
+	metrics.ServiceOnline.WithLabelValues(name).Set(1)
+	if !wasOnline {
+		a.events.publish(Event{Type: EventServiceOnline, Service: name, Timestamp: time.Now()})
+	}
+
+	svc.Locked = result.Locked
+	metrics.ServiceLocked.WithLabelValues(name).Set(boolToFloat(result.Locked))
+
+	wasActive := svc.Active
+	svc.Active = result.Active
+	metrics.ServiceActive.WithLabelValues(name).Set(boolToFloat(result.Active))
+
+	// Auto mode exclusively owns activeService via evaluateAuto; in
+	// manual mode, opportunistically track whichever service the device
+	// itself reports as active and unlocked.
+	if a.cfg.Arbitration.Mode != modeAuto {
+		if svc.Active && !svc.Locked {
+			if a.activeService != name {
+				a.activeService = name
+				a.events.publish(Event{Type: EventActiveServiceChanged, Service: name, Timestamp: time.Now()})
+			}
+		} else if a.activeService == name && !svc.Active {
+			a.activeService = ""
+			a.events.publish(Event{Type: EventActiveServiceChanged, Timestamp: time.Now()})
+		}
 	}
 
-	// Check locked status
-	if locked, ok := status["locked"].(bool); ok {
-		svc.Locked = locked
+	if svc.Active != wasActive {
+		log.Debug().Str("service", name).Bool("active", svc.Active).Msg("service activity changed")
 	}
+}
 
-	// Check if service is actively doing something
-	// For usbOverI2S: check player state
-	if state, ok := status["state"].(string); ok {
-		svc.Active = state == "playing"
-	}
-	// For usbAudio: check active field
-	if active, ok := status["active"].(bool); ok {
-		svc.Active = active
+// notePollFailure publishes the events for a failed poll of a service
+// the caller has already marked offline. Must be called with the mutex
+// held.
+func (a *Arbiter) notePollFailure(name string, wasOnline bool, reason string) {
+	if wasOnline {
+		a.events.publish(Event{Type: EventServiceOffline, Service: name, Timestamp: time.Now()})
 	}
+	a.events.publish(Event{Type: EventPollError, Service: name, Timestamp: time.Now(), Data: reason})
+}
 
-	// Update active service tracking
-	if svc.Active && !svc.Locked {
-		a.activeService = name
-	} else if a.activeService == name && !svc.Active {
-		a.activeService = ""
+// boolToFloat converts a bool to the 1/0 float64 Prometheus gauges expect.
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
 	}
+	return 0
 }
@@ -0,0 +1,178 @@
+package arbiter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ActivationSource records what triggered an activation, for logging,
+// events and metrics.
+type ActivationSource string
+
+const (
+	SourceAPI     ActivationSource = "api"
+	SourceAuto    ActivationSource = "auto"
+	SourceEnforce ActivationSource = "enforce"
+)
+
+const (
+	modeManual = "manual"
+	modeAuto   = "auto"
+)
+
+// evaluateAuto picks the winning service for auto arbitration mode and
+// preempts the current one if warranted. Must be called with the mutex
+// held, after polling has settled Active state for this cycle.
+func (a *Arbiter) evaluateAuto(now time.Time) {
+	if a.cfg.Arbitration.Mode != modeAuto {
+		return
+	}
+
+	current := a.activeService
+	if current != "" {
+		if svc, ok := a.services[current]; ok {
+			a.trackIdle(current, svc.Active, now)
+		}
+	}
+
+	best := a.bestCandidate()
+	if best == "" {
+		// Nothing is requesting playback; leave the current winner
+		// alone rather than tearing it down pre-emptively.
+		return
+	}
+
+	if current == "" {
+		a.preempt(current, best, now, "no active winner")
+		return
+	}
+
+	if current == best {
+		return
+	}
+
+	if !a.canPreempt(current, best, now) {
+		return
+	}
+
+	a.preempt(current, best, now, "priority preemption")
+}
+
+// bestCandidate returns the highest-priority (lowest Priority value)
+// online, Active service, or "" if none are requesting playback.
+func (a *Arbiter) bestCandidate() string {
+	var best string
+	for name, svc := range a.services {
+		if !svc.Online || !svc.Active {
+			continue
+		}
+		if best == "" || svc.Priority < a.services[best].Priority {
+			best = name
+		}
+	}
+	return best
+}
+
+// trackIdle updates how long the current winner has been idle (not
+// Active), which canPreempt uses to decide whether it has debounced long
+// enough to be displaced. Called every cycle so idle time keeps
+// accumulating even while min_hold_ms is still blocking preemption.
+func (a *Arbiter) trackIdle(name string, active bool, now time.Time) {
+	if active {
+		delete(a.autoIdleSince, name)
+		a.autoIdleCycles[name] = 0
+		return
+	}
+	if _, tracked := a.autoIdleSince[name]; !tracked {
+		a.autoIdleSince[name] = now
+	}
+	a.autoIdleCycles[name]++
+}
+
+// canPreempt applies the configured hysteresis to decide whether the
+// current winner can be displaced by challenger this cycle. Nothing
+// preempts the incumbent before MinHoldMs has elapsed. In Sticky mode the
+// incumbent then holds until it has been idle for StickyIdleCycles
+// consecutive cycles, whatever the challenger's priority. Otherwise a
+// higher-priority challenger takes over as soon as MinHoldMs has elapsed,
+// regardless of whether the incumbent is still Active, and PreemptAfterMs
+// only protects an incumbent from a challenger of equal or lower priority.
+func (a *Arbiter) canPreempt(current, challenger string, now time.Time) bool {
+	held := now.Sub(a.autoWinnerSince[current])
+	if held < time.Duration(a.cfg.Arbitration.MinHoldMs)*time.Millisecond {
+		return false
+	}
+
+	currentSvc, ok := a.services[current]
+	if !ok {
+		return true
+	}
+
+	if a.cfg.Arbitration.Sticky {
+		return a.autoIdleCycles[current] >= a.cfg.Arbitration.StickyIdleCycles
+	}
+
+	if a.services[challenger].Priority < currentSvc.Priority {
+		return true
+	}
+
+	idleSince, idle := a.autoIdleSince[current]
+	if !idle {
+		return false
+	}
+	return now.Sub(idleSince) >= time.Duration(a.cfg.Arbitration.PreemptAfterMs)*time.Millisecond
+}
+
+// preempt switches the active winner to next, logging and emitting an
+// event describing the decision.
+func (a *Arbiter) preempt(from, next string, now time.Time, reason string) {
+	logEvt := log.Info().Str("to", next).Str("reason", reason)
+	if from != "" {
+		logEvt = logEvt.Str("from", from)
+	}
+	logEvt.Msg("auto arbitration preempting")
+
+	if err := a.activateServiceLocked(next, SourceAuto); err != nil {
+		log.Warn().Err(err).Str("service", next).Msg("auto arbitration activation failed")
+		return
+	}
+
+	a.autoWinnerSince[next] = now
+	delete(a.autoIdleSince, next)
+	a.autoIdleCycles[next] = 0
+
+	a.events.publish(Event{
+		Type:      EventAutoLockEnforced,
+		Service:   next,
+		Timestamp: now,
+		Data:      map[string]string{"reason": reason, "preempted": from},
+	})
+}
+
+// SetMode switches the arbiter between "manual" and "auto" arbitration.
+func (a *Arbiter) SetMode(mode string) error {
+	if mode != modeManual && mode != modeAuto {
+		return fmt.Errorf("unknown arbitration mode: %s", mode)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.cfg.Arbitration.Mode = mode
+	if mode == modeAuto {
+		now := a.clock.Now()
+		if a.activeService != "" {
+			a.autoWinnerSince[a.activeService] = now
+		}
+	}
+	return nil
+}
+
+// Mode returns the arbiter's current arbitration mode.
+func (a *Arbiter) Mode() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.cfg.Arbitration.Mode
+}
@@ -0,0 +1,202 @@
+package arbiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zditech/i2sarbitor/internal/arbiter/driver"
+	"github.com/zditech/i2sarbitor/internal/config"
+)
+
+// fakeDriver is a Driver whose Poll/SetLocked results are controlled
+// directly by the test, without making any network calls.
+type fakeDriver struct {
+	name   string
+	locked bool
+	active bool
+}
+
+func (d *fakeDriver) Name() string { return d.name }
+
+func (d *fakeDriver) Poll(ctx context.Context, baseURL string) (driver.PollResult, error) {
+	return driver.PollResult{Locked: d.locked, Active: d.active}, nil
+}
+
+func (d *fakeDriver) SetLocked(ctx context.Context, baseURL string, locked bool) error {
+	d.locked = locked
+	return nil
+}
+
+// fakeClock is a Clock whose value only advances when the test tells it to.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// newTestArbiter builds an Arbiter wired to fakeDrivers and a fakeClock,
+// in auto mode with the given hysteresis settings.
+func newTestArbiter(t *testing.T, arbitration config.ArbitrationConfig, drivers map[string]*fakeDriver) (*Arbiter, *fakeClock) {
+	t.Helper()
+
+	cfg := &config.Config{Arbitration: arbitration}
+	for name := range drivers {
+		cfg.Services = append(cfg.Services, config.Service{Name: name, Priority: 0})
+	}
+
+	a := New(cfg)
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	a.clock = clock
+
+	for name, d := range drivers {
+		a.drivers[name] = d
+	}
+
+	// Every service starts at Priority 0; tests set priorities explicitly
+	// on a.services after this returns.
+	return a, clock
+}
+
+func TestEvaluateAuto_PicksHighestPriorityActiveService(t *testing.T) {
+	high := &fakeDriver{name: "high", active: true}
+	low := &fakeDriver{name: "low", active: true}
+
+	a, clock := newTestArbiter(t, config.ArbitrationConfig{Mode: "auto", MinHoldMs: 0, PreemptAfterMs: 0}, map[string]*fakeDriver{
+		"high": high,
+		"low":  low,
+	})
+	a.services["high"].Priority = 1
+	a.services["low"].Priority = 2
+
+	a.pollAllServices()
+
+	if got := a.GetActiveService(); got != "high" {
+		t.Fatalf("expected high priority service to win, got %q", got)
+	}
+	_ = clock
+}
+
+func TestEvaluateAuto_MinHoldPreventsImmediatePreemption(t *testing.T) {
+	low := &fakeDriver{name: "low", active: true}
+	high := &fakeDriver{name: "high", active: false}
+
+	a, clock := newTestArbiter(t, config.ArbitrationConfig{Mode: "auto", MinHoldMs: 1000, PreemptAfterMs: 0}, map[string]*fakeDriver{
+		"low":  low,
+		"high": high,
+	})
+	a.services["low"].Priority = 2
+	a.services["high"].Priority = 1
+
+	// low wins first since high isn't active yet.
+	a.pollAllServices()
+	if got := a.GetActiveService(); got != "low" {
+		t.Fatalf("expected low to win initially, got %q", got)
+	}
+
+	// low goes idle and high becomes active; should not preempt yet
+	// because low hasn't held the lock for MinHoldMs.
+	low.active = false
+	high.active = true
+	a.pollAllServices()
+	if got := a.GetActiveService(); got != "low" {
+		t.Fatalf("expected low to still hold the lock inside min-hold window, got %q", got)
+	}
+
+	// After the hold window elapses, high should preempt.
+	clock.Advance(2 * time.Second)
+	a.pollAllServices()
+	if got := a.GetActiveService(); got != "high" {
+		t.Fatalf("expected high to preempt low after min-hold elapsed, got %q", got)
+	}
+}
+
+func TestEvaluateAuto_StickyHoldsUntilIdleCyclesElapse(t *testing.T) {
+	// challenger is lower priority than current, so sticky's idle-cycle
+	// debounce is what's under test here (priority alone would never
+	// hand challenger the win).
+	current := &fakeDriver{name: "current", active: true}
+	challenger := &fakeDriver{name: "challenger", active: false}
+
+	a, _ := newTestArbiter(t, config.ArbitrationConfig{
+		Mode:             "auto",
+		MinHoldMs:        0,
+		Sticky:           true,
+		StickyIdleCycles: 2,
+	}, map[string]*fakeDriver{
+		"current":    current,
+		"challenger": challenger,
+	})
+	a.services["current"].Priority = 1
+	a.services["challenger"].Priority = 2
+
+	// current is the only one requesting playback, so it wins first.
+	a.pollAllServices()
+	if got := a.GetActiveService(); got != "current" {
+		t.Fatalf("expected current to win the first cycle, got %q", got)
+	}
+
+	// challenger starts requesting too, but current outranks it and is
+	// still active, so current keeps the lock.
+	challenger.active = true
+	a.pollAllServices()
+	if got := a.GetActiveService(); got != "current" {
+		t.Fatalf("expected current to stay active while still requesting, got %q", got)
+	}
+
+	current.active = false
+	a.pollAllServices() // idle cycle 1
+	if got := a.GetActiveService(); got != "current" {
+		t.Fatalf("expected current to stay active after first idle cycle, got %q", got)
+	}
+
+	a.pollAllServices() // idle cycle 2
+	if got := a.GetActiveService(); got != "challenger" {
+		t.Fatalf("expected challenger to take over after sticky idle cycles elapsed, got %q", got)
+	}
+}
+
+func TestEvaluateAuto_HigherPriorityPreemptsWithoutWaitingForIdle(t *testing.T) {
+	// PreemptAfterMs is large enough that idle-gating alone would never
+	// let high win; a higher-priority challenger must preempt anyway once
+	// MinHoldMs elapses, since idle-gating only protects an incumbent
+	// from a lower-priority (or equal) challenger.
+	low := &fakeDriver{name: "low", active: true}
+	high := &fakeDriver{name: "high", active: false}
+
+	a, clock := newTestArbiter(t, config.ArbitrationConfig{
+		Mode:           "auto",
+		MinHoldMs:      1000,
+		PreemptAfterMs: 60000,
+	}, map[string]*fakeDriver{
+		"low":  low,
+		"high": high,
+	})
+	a.services["low"].Priority = 2
+	a.services["high"].Priority = 1
+
+	a.pollAllServices()
+	if got := a.GetActiveService(); got != "low" {
+		t.Fatalf("expected low to win initially, got %q", got)
+	}
+
+	// high starts requesting too; low never goes idle.
+	high.active = true
+	clock.Advance(2 * time.Second)
+	a.pollAllServices()
+	if got := a.GetActiveService(); got != "high" {
+		t.Fatalf("expected high priority challenger to preempt low without waiting for it to idle, got %q", got)
+	}
+}
+
+func TestSetMode_RejectsUnknownMode(t *testing.T) {
+	a := New(config.Default())
+	if err := a.SetMode("yolo"); err == nil {
+		t.Fatal("expected an error for an unknown mode")
+	}
+	if got := a.Mode(); got != "manual" {
+		t.Fatalf("expected mode to remain unchanged, got %q", got)
+	}
+}
@@ -0,0 +1,14 @@
+package arbiter
+
+import "time"
+
+// Clock abstracts time.Now so the auto arbitration hysteresis logic can
+// be driven deterministically in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the production Clock, backed by the wall clock.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
@@ -0,0 +1,112 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/rs/zerolog/log"
+
+	"github.com/zditech/i2sarbitor/internal/config"
+)
+
+// consulPollInterval is how often the catalog is re-queried, since
+// tag-filtered catalog lookups have no long-poll in this client.
+const consulPollInterval = 10 * time.Second
+
+// ConsulDiscovery watches the Consul service catalog for entries tagged
+// with Tag.
+type ConsulDiscovery struct {
+	Tag    string
+	client *consulapi.Client
+}
+
+// NewConsulDiscovery creates a Discovery backed by a Consul catalog
+// lookup against address (empty uses the client library's defaults,
+// typically CONSUL_HTTP_ADDR or localhost:8500), filtered by tag.
+func NewConsulDiscovery(address, tag string) (*ConsulDiscovery, error) {
+	cfg := consulapi.DefaultConfig()
+	if address != "" {
+		cfg.Address = address
+	}
+
+	client, err := consulapi.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consul client: %w", err)
+	}
+
+	return &ConsulDiscovery{Tag: tag, client: client}, nil
+}
+
+// Watch implements Discovery.
+func (d *ConsulDiscovery) Watch(ctx context.Context) <-chan []config.Service {
+	out := make(chan []config.Service, 1)
+
+	go func() {
+		defer close(out)
+
+		ticker := time.NewTicker(consulPollInterval)
+		defer ticker.Stop()
+
+		d.lookup(ctx, out)
+
+		for {
+			select {
+			case <-ticker.C:
+				d.lookup(ctx, out)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// SuppliesPriority implements Discovery. Consul catalog entries carry no
+// notion of priority or driver, so lookup always leaves those fields
+// zero-valued.
+func (d *ConsulDiscovery) SuppliesPriority() bool { return false }
+
+func (d *ConsulDiscovery) lookup(ctx context.Context, out chan<- []config.Service) {
+	names, _, err := d.client.Catalog().Services(nil)
+	if err != nil {
+		log.Warn().Err(err).Msg("consul catalog services lookup failed")
+		return
+	}
+
+	var services []config.Service
+	for name, tags := range names {
+		if !hasTag(tags, d.Tag) {
+			continue
+		}
+
+		entries, _, err := d.client.Catalog().Service(name, d.Tag, nil)
+		if err != nil {
+			log.Warn().Err(err).Str("service", name).Msg("consul catalog service lookup failed")
+			continue
+		}
+
+		for _, entry := range entries {
+			services = append(services, config.Service{
+				Name:    entry.ServiceID,
+				BaseURL: fmt.Sprintf("http://%s:%d", entry.ServiceAddress, entry.ServicePort),
+			})
+		}
+	}
+
+	select {
+	case out <- services:
+	case <-ctx.Done():
+	}
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+// Package discovery provides pluggable backends that watch an external
+// source of truth for the set of services the arbiter should manage, as
+// an alternative to a static YAML list.
+package discovery
+
+import (
+	"context"
+
+	"github.com/zditech/i2sarbitor/internal/config"
+)
+
+// Discovery watches an external source for the services that should be
+// managed by the arbiter.
+type Discovery interface {
+	// Watch starts watching for service changes and returns a channel
+	// that receives the complete, current service list each time it
+	// changes. The channel is closed once ctx is cancelled.
+	Watch(ctx context.Context) <-chan []config.Service
+
+	// SuppliesPriority reports whether the config.Service values sent on
+	// Watch's channel carry accurate Priority (and Driver). Backends
+	// whose source format has no such concept (mDNS, Consul) return
+	// false, so the arbiter won't clobber an operator-assigned Priority
+	// on a surviving service with the zero value.
+	SuppliesPriority() bool
+}
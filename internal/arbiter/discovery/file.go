@@ -0,0 +1,104 @@
+package discovery
+
+import (
+	"context"
+	"os"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+
+	"github.com/zditech/i2sarbitor/internal/config"
+)
+
+// FileDiscovery watches a YAML services file on disk and re-parses it
+// whenever it changes, so the service list can be edited without
+// restarting the arbiter.
+type FileDiscovery struct {
+	Path string
+}
+
+// NewFileDiscovery creates a Discovery backed by the services list in the
+// YAML file at path.
+func NewFileDiscovery(path string) *FileDiscovery {
+	return &FileDiscovery{Path: path}
+}
+
+// Watch implements Discovery.
+func (d *FileDiscovery) Watch(ctx context.Context) <-chan []config.Service {
+	out := make(chan []config.Service, 1)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create file watcher for service discovery")
+		close(out)
+		return out
+	}
+
+	if err := watcher.Add(d.Path); err != nil {
+		log.Error().Err(err).Str("path", d.Path).Msg("failed to watch services file")
+		watcher.Close()
+		close(out)
+		return out
+	}
+
+	emit := func() {
+		services, err := loadServices(d.Path)
+		if err != nil {
+			log.Warn().Err(err).Str("path", d.Path).Msg("failed to reload services file")
+			return
+		}
+		select {
+		case out <- services:
+		case <-ctx.Done():
+		}
+	}
+
+	go func() {
+		defer watcher.Close()
+		defer close(out)
+
+		emit()
+
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+					emit()
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Warn().Err(err).Msg("service file watcher error")
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// SuppliesPriority implements Discovery. FileDiscovery re-parses the same
+// YAML services schema as the static config, so Priority and Driver are
+// always accurate.
+func (d *FileDiscovery) SuppliesPriority() bool { return true }
+
+func loadServices(path string) ([]config.Service, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc struct {
+		Services []config.Service `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc.Services, nil
+}
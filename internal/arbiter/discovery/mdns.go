@@ -0,0 +1,105 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/mdns"
+	"github.com/rs/zerolog/log"
+
+	"github.com/zditech/i2sarbitor/internal/config"
+)
+
+// mdnsBrowseInterval is how often the local network is re-browsed, since
+// mDNS has no long-lived subscription for a fixed service type.
+const mdnsBrowseInterval = 30 * time.Second
+
+// MDNSDiscovery browses the local network for services advertising
+// themselves via mDNS/DNS-SD under a configured service type (e.g.
+// "_i2sarbitor._tcp"), so USB audio bridges are picked up automatically.
+type MDNSDiscovery struct {
+	ServiceType string
+}
+
+// NewMDNSDiscovery creates a Discovery that browses for serviceType.
+func NewMDNSDiscovery(serviceType string) *MDNSDiscovery {
+	return &MDNSDiscovery{ServiceType: serviceType}
+}
+
+// Watch implements Discovery.
+func (d *MDNSDiscovery) Watch(ctx context.Context) <-chan []config.Service {
+	out := make(chan []config.Service, 1)
+
+	go func() {
+		defer close(out)
+
+		ticker := time.NewTicker(mdnsBrowseInterval)
+		defer ticker.Stop()
+
+		d.browse(ctx, out)
+
+		for {
+			select {
+			case <-ticker.C:
+				d.browse(ctx, out)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// SuppliesPriority implements Discovery. mDNS service records carry no
+// notion of priority or driver, so entryToService always leaves those
+// fields zero-valued.
+func (d *MDNSDiscovery) SuppliesPriority() bool { return false }
+
+func (d *MDNSDiscovery) browse(ctx context.Context, out chan<- []config.Service) {
+	entriesCh := make(chan *mdns.ServiceEntry, 16)
+
+	done := make(chan struct{})
+	var services []config.Service
+	go func() {
+		defer close(done)
+		for entry := range entriesCh {
+			services = append(services, entryToService(entry))
+		}
+	}()
+
+	// Lookup never closes entriesCh itself, so close it once Lookup
+	// returns to let the collecting goroutine finish.
+	err := mdns.Lookup(d.ServiceType, entriesCh)
+	close(entriesCh)
+	<-done
+	if err != nil {
+		log.Warn().Err(err).Str("service_type", d.ServiceType).Msg("mdns lookup failed")
+		return
+	}
+
+	select {
+	case out <- services:
+	case <-ctx.Done():
+	}
+}
+
+func entryToService(entry *mdns.ServiceEntry) config.Service {
+	name := entry.Name
+	if idx := strings.Index(name, "."); idx != -1 {
+		name = name[:idx]
+	}
+
+	addr := entry.AddrV4.String()
+	if entry.AddrV4 == nil {
+		addr = entry.AddrV6.String()
+	}
+
+	return config.Service{
+		Name:        name,
+		DisplayName: entry.Name,
+		BaseURL:     fmt.Sprintf("http://%s:%d", addr, entry.Port),
+	}
+}
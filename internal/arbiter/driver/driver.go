@@ -0,0 +1,38 @@
+// Package driver abstracts the per-service-type HTTP API that the arbiter
+// polls and locks, so new I2S consumers can be added without editing the
+// arbiter's core logic.
+package driver
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// httpTimeout bounds every HTTP-backed driver's status/lock requests, so a
+// single unresponsive service can't stall the arbiter's poll loop (which
+// runs the driver calls under its write lock) indefinitely.
+const httpTimeout = 2 * time.Second
+
+// newHTTPClient returns an *http.Client configured with httpTimeout, for
+// HTTP-backed drivers to share instead of http.DefaultClient.
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: httpTimeout}
+}
+
+// PollResult is the normalized outcome of polling a service's status
+// endpoint, independent of the wire format any particular driver speaks.
+type PollResult struct {
+	Locked bool
+	Active bool
+}
+
+// Driver knows how to poll and lock a specific kind of I2S service.
+type Driver interface {
+	// Poll fetches and normalizes the current status of the service at baseURL.
+	Poll(ctx context.Context, baseURL string) (PollResult, error)
+	// SetLocked locks or unlocks the service at baseURL.
+	SetLocked(ctx context.Context, baseURL string, locked bool) error
+	// Name returns the driver's registry key.
+	Name() string
+}
@@ -0,0 +1,121 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// GenericHTTPConfig declares the URL templates and JSON field mappings a
+// generic-http driver instance uses, so new I2S consumers can be added
+// through YAML alone.
+type GenericHTTPConfig struct {
+	StatusPath       string `yaml:"status_path"`
+	LockPath         string `yaml:"lock_path"`
+	LockedField      string `yaml:"locked_field"`
+	ActiveField      string `yaml:"active_field"`
+	StateField       string `yaml:"state_field"`
+	ActiveStateValue string `yaml:"active_state_value"`
+	DataWrapper      string `yaml:"data_wrapper"`
+}
+
+// genericHTTPDriver is a Driver configured entirely from GenericHTTPConfig.
+// It is the default driver for services that don't name one of the
+// purpose-built drivers.
+type genericHTTPDriver struct {
+	cfg    GenericHTTPConfig
+	client *http.Client
+}
+
+// NewGenericHTTP creates a Driver from cfg, filling in the same defaults
+// the arbiter used before drivers existed (status at /api/v1/status,
+// lock at /api/v1/lock, "locked"/"active" JSON fields).
+func NewGenericHTTP(cfg GenericHTTPConfig) Driver {
+	if cfg.StatusPath == "" {
+		cfg.StatusPath = "/api/v1/status"
+	}
+	if cfg.LockPath == "" {
+		cfg.LockPath = "/api/v1/lock"
+	}
+	if cfg.LockedField == "" {
+		cfg.LockedField = "locked"
+	}
+	if cfg.ActiveField == "" {
+		cfg.ActiveField = "active"
+	}
+	return &genericHTTPDriver{cfg: cfg, client: newHTTPClient()}
+}
+
+// Name implements Driver.
+func (d *genericHTTPDriver) Name() string { return "generic-http" }
+
+// Poll implements Driver.
+func (d *genericHTTPDriver) Poll(ctx context.Context, baseURL string) (PollResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+d.cfg.StatusPath, nil)
+	if err != nil {
+		return PollResult{}, err
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return PollResult{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return PollResult{}, fmt.Errorf("status request failed: %s", string(body))
+	}
+
+	var status map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return PollResult{}, err
+	}
+
+	if d.cfg.DataWrapper != "" {
+		if wrapped, ok := status[d.cfg.DataWrapper].(map[string]interface{}); ok {
+			status = wrapped
+		}
+	}
+
+	var result PollResult
+	if locked, ok := status[d.cfg.LockedField].(bool); ok {
+		result.Locked = locked
+	}
+	if d.cfg.StateField != "" {
+		if state, ok := status[d.cfg.StateField].(string); ok {
+			result.Active = state == d.cfg.ActiveStateValue
+		}
+	}
+	if active, ok := status[d.cfg.ActiveField].(bool); ok {
+		result.Active = active
+	}
+	return result, nil
+}
+
+// SetLocked implements Driver.
+func (d *genericHTTPDriver) SetLocked(ctx context.Context, baseURL string, locked bool) error {
+	payload := map[string]bool{d.cfg.LockedField: locked}
+	body, _ := json.Marshal(payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+d.cfg.LockPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("lock request failed: %s", string(respBody))
+	}
+	return nil
+}
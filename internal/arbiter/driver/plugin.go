@@ -0,0 +1,32 @@
+//go:build linux || darwin
+
+package driver
+
+import (
+	"fmt"
+	"plugin"
+)
+
+// LoadPlugin loads a Driver implementation from a Go plugin (.so) built
+// with `go build -buildmode=plugin`. The plugin must export a symbol
+// named "Driver" implementing the Driver interface; it is registered
+// under its own Name().
+func LoadPlugin(path string) error {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open driver plugin %s: %w", path, err)
+	}
+
+	sym, err := p.Lookup("Driver")
+	if err != nil {
+		return fmt.Errorf("driver plugin %s has no Driver symbol: %w", path, err)
+	}
+
+	d, ok := sym.(Driver)
+	if !ok {
+		return fmt.Errorf("driver plugin %s does not implement Driver", path)
+	}
+
+	Register(d)
+	return nil
+}
@@ -0,0 +1,11 @@
+//go:build !linux && !darwin
+
+package driver
+
+import "fmt"
+
+// LoadPlugin is unavailable on this platform: Go plugins only load on
+// linux and darwin.
+func LoadPlugin(path string) error {
+	return fmt.Errorf("driver plugins are not supported on this platform")
+}
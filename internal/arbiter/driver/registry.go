@@ -0,0 +1,31 @@
+package driver
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Driver)
+)
+
+// Register adds a driver to the registry under its own Name(). It is
+// typically called from a driver implementation's init function.
+func Register(d Driver) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[d.Name()] = d
+}
+
+// Get looks up a registered driver by name.
+func Get(name string) (Driver, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	d, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown driver: %s", name)
+	}
+	return d, nil
+}
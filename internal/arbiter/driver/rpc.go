@@ -0,0 +1,111 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os/exec"
+)
+
+// rpcPollArgs is the payload for the "Driver.Poll" JSON-RPC method.
+type rpcPollArgs struct {
+	BaseURL string
+}
+
+// rpcSetLockedArgs is the payload for the "Driver.SetLocked" JSON-RPC method.
+type rpcSetLockedArgs struct {
+	BaseURL string
+	Locked  bool
+}
+
+// RPCDriver speaks to an out-of-process driver over a small JSON-RPC
+// contract ("Driver.Poll" and "Driver.SetLocked" over stdin/stdout), for
+// consumer integrations that can't ship a Go plugin.
+type RPCDriver struct {
+	name   string
+	cmd    *exec.Cmd
+	client *rpc.Client
+}
+
+// NewRPCDriver launches command as a subprocess speaking JSON-RPC over
+// stdin/stdout, registers it under name, and returns it.
+func NewRPCDriver(name, command string, args ...string) (*RPCDriver, error) {
+	cmd := exec.Command(command, args...)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start driver process %s: %w", command, err)
+	}
+
+	d := &RPCDriver{
+		name:   name,
+		cmd:    cmd,
+		client: jsonrpc.NewClient(rpcConn{stdout, stdin}),
+	}
+	Register(d)
+	return d, nil
+}
+
+// Name implements Driver.
+func (d *RPCDriver) Name() string { return d.name }
+
+// Poll implements Driver.
+func (d *RPCDriver) Poll(ctx context.Context, baseURL string) (PollResult, error) {
+	var result PollResult
+	err := d.call(ctx, "Driver.Poll", rpcPollArgs{BaseURL: baseURL}, &result)
+	return result, err
+}
+
+// SetLocked implements Driver.
+func (d *RPCDriver) SetLocked(ctx context.Context, baseURL string, locked bool) error {
+	var reply struct{}
+	return d.call(ctx, "Driver.SetLocked", rpcSetLockedArgs{BaseURL: baseURL, Locked: locked}, &reply)
+}
+
+// call invokes serviceMethod through the RPC client and honors ctx's
+// deadline: net/rpc's Client.Call blocks until the subprocess replies,
+// which would otherwise let a wedged driver stall the arbiter (Poll and
+// SetLocked run while it holds its write lock) forever. The in-flight
+// call is left running if ctx is done first; the reply is discarded when
+// it eventually arrives.
+func (d *RPCDriver) call(ctx context.Context, serviceMethod string, args, reply interface{}) error {
+	rpcCall := d.client.Go(serviceMethod, args, reply, make(chan *rpc.Call, 1))
+	select {
+	case <-rpcCall.Done:
+		return rpcCall.Error
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops the driver subprocess and its RPC client.
+func (d *RPCDriver) Close() error {
+	closeErr := d.client.Close()
+	d.cmd.Wait()
+	return closeErr
+}
+
+// rpcConn combines a subprocess's stdout/stdin pipes into the
+// io.ReadWriteCloser the jsonrpc codec expects.
+type rpcConn struct {
+	io.ReadCloser
+	io.WriteCloser
+}
+
+func (c rpcConn) Close() error {
+	werr := c.WriteCloser.Close()
+	rerr := c.ReadCloser.Close()
+	if werr != nil {
+		return werr
+	}
+	return rerr
+}
@@ -0,0 +1,81 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+func init() {
+	Register(&USBAudioDriver{client: newHTTPClient()})
+}
+
+// USBAudioDriver speaks the usbAudio bridge status/lock API:
+// GET /api/v1/status returning {"locked":...,"active":...},
+// and POST /api/v1/lock with {"locked":bool} to lock/unlock.
+type USBAudioDriver struct {
+	client *http.Client
+}
+
+// Name implements Driver.
+func (d *USBAudioDriver) Name() string { return "usbaudio" }
+
+// Poll implements Driver.
+func (d *USBAudioDriver) Poll(ctx context.Context, baseURL string) (PollResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/api/v1/status", nil)
+	if err != nil {
+		return PollResult{}, err
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return PollResult{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return PollResult{}, fmt.Errorf("status request failed: %s", string(body))
+	}
+
+	var status map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return PollResult{}, err
+	}
+
+	var result PollResult
+	if locked, ok := status["locked"].(bool); ok {
+		result.Locked = locked
+	}
+	if active, ok := status["active"].(bool); ok {
+		result.Active = active
+	}
+	return result, nil
+}
+
+// SetLocked implements Driver.
+func (d *USBAudioDriver) SetLocked(ctx context.Context, baseURL string, locked bool) error {
+	payload := map[string]bool{"locked": locked}
+	body, _ := json.Marshal(payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/api/v1/lock", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("lock request failed: %s", string(respBody))
+	}
+	return nil
+}
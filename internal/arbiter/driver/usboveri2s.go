@@ -0,0 +1,79 @@
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+func init() {
+	Register(&USBOverI2SDriver{client: newHTTPClient()})
+}
+
+// USBOverI2SDriver speaks the usbOverI2S player status/lock API:
+// GET /api/v1/player/status returning {"success":true,"data":{"locked":...,"state":...}},
+// and POST/DELETE /api/v1/lock to lock/unlock.
+type USBOverI2SDriver struct {
+	client *http.Client
+}
+
+// Name implements Driver.
+func (d *USBOverI2SDriver) Name() string { return "usboveri2s" }
+
+// Poll implements Driver.
+func (d *USBOverI2SDriver) Poll(ctx context.Context, baseURL string) (PollResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/api/v1/player/status", nil)
+	if err != nil {
+		return PollResult{}, err
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return PollResult{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return PollResult{}, fmt.Errorf("status request failed: %s", string(body))
+	}
+
+	var payload struct {
+		Data struct {
+			Locked bool   `json:"locked"`
+			State  string `json:"state"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return PollResult{}, err
+	}
+
+	return PollResult{Locked: payload.Data.Locked, Active: payload.Data.State == "playing"}, nil
+}
+
+// SetLocked implements Driver.
+func (d *USBOverI2SDriver) SetLocked(ctx context.Context, baseURL string, locked bool) error {
+	method := http.MethodPost
+	if !locked {
+		method = http.MethodDelete
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+"/api/v1/lock", nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("lock request failed: %s", string(body))
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package arbiter
+
+import (
+	"sync"
+	"time"
+)
+
+// EventType identifies the kind of state change an Event describes.
+type EventType string
+
+const (
+	EventServiceOnline        EventType = "service_online"
+	EventServiceOffline       EventType = "service_offline"
+	EventServiceLocked        EventType = "locked"
+	EventServiceUnlocked      EventType = "unlocked"
+	EventActiveServiceChanged EventType = "active_service_changed"
+	EventAutoLockEnforced     EventType = "auto_lock_enforced"
+	EventPollError            EventType = "poll_error"
+)
+
+// Event describes a single arbiter state change. Events are published to
+// subscribers in the order they occur but delivery is best-effort: a slow
+// subscriber drops events rather than blocking the poll loop.
+type Event struct {
+	Type      EventType   `json:"type"`
+	Service   string      `json:"service,omitempty"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+// eventHub is a fan-out pub/sub for Events.
+type eventHub struct {
+	mu     sync.RWMutex
+	subs   map[int]chan Event
+	nextID int
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{subs: make(map[int]chan Event)}
+}
+
+// subscribe registers a new subscriber and returns a channel of events
+// along with a cancel function that must be called to release it.
+func (h *eventHub) subscribe() (<-chan Event, func()) {
+	h.mu.Lock()
+	id := h.nextID
+	h.nextID++
+	ch := make(chan Event, 32)
+	h.subs[id] = ch
+	h.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subs, id)
+			h.mu.Unlock()
+			close(ch)
+		})
+	}
+	return ch, cancel
+}
+
+// publish fans an event out to all current subscribers without blocking.
+func (h *eventHub) publish(evt Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for _, ch := range h.subs {
+		select {
+		case ch <- evt:
+		default:
+			// Subscriber isn't keeping up; drop the event rather than
+			// stall polling on a slow SSE/WebSocket client.
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Package audit streams arbiter events to a rotated JSON-lines log file for
+// operators who need a durable record of activations and lock changes.
+package audit
+
+import (
+	"encoding/json"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/rs/zerolog/log"
+	"github.com/zditech/i2sarbitor/internal/arbiter"
+	"github.com/zditech/i2sarbitor/internal/config"
+)
+
+// Sink subscribes to an Arbiter's event stream and appends each event as a
+// JSON line to a rotated log file.
+type Sink struct {
+	writer *lumberjack.Logger
+	cancel func()
+}
+
+// NewSink starts a Sink writing arb's events to cfg.Path. It returns nil if
+// cfg.Path is empty, disabling the audit log entirely.
+func NewSink(cfg config.AuditConfig, arb *arbiter.Arbiter) *Sink {
+	if cfg.Path == "" {
+		return nil
+	}
+
+	w := &lumberjack.Logger{
+		Filename:   cfg.Path,
+		MaxSize:    cfg.MaxSizeMB,
+		MaxBackups: cfg.MaxBackups,
+		MaxAge:     cfg.MaxAgeDays,
+	}
+
+	events, cancel := arb.Subscribe()
+	s := &Sink{writer: w, cancel: cancel}
+
+	go func() {
+		enc := json.NewEncoder(w)
+		for evt := range events {
+			if err := enc.Encode(evt); err != nil {
+				log.Warn().Err(err).Msg("failed to write audit log entry")
+			}
+		}
+	}()
+
+	log.Info().Str("path", cfg.Path).Msg("audit log started")
+	return s
+}
+
+// Close stops the sink and closes the underlying log file.
+func (s *Sink) Close() error {
+	s.cancel()
+	return s.writer.Close()
+}
@@ -4,6 +4,8 @@ import (
 	"os"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/zditech/i2sarbitor/internal/arbiter/driver"
 )
 
 // Service represents a managed I2S service
@@ -12,14 +14,100 @@ type Service struct {
 	DisplayName string `yaml:"display_name"`
 	BaseURL     string `yaml:"base_url"`
 	Priority    int    `yaml:"priority"`
+
+	// Driver selects the registered driver.Driver used to poll and lock
+	// this service. Defaults to "generic-http" if unset.
+	Driver string `yaml:"driver"`
+	// GenericHTTP configures the "generic-http" driver; ignored otherwise.
+	GenericHTTP *driver.GenericHTTPConfig `yaml:"generic_http,omitempty"`
+}
+
+// MDNSDiscoveryConfig configures mDNS/DNS-SD service browsing
+type MDNSDiscoveryConfig struct {
+	ServiceType string `yaml:"service_type"`
+}
+
+// ConsulDiscoveryConfig configures Consul catalog lookups
+type ConsulDiscoveryConfig struct {
+	Address string `yaml:"address"`
+	Tag     string `yaml:"tag"`
+}
+
+// DiscoveryConfig selects and configures a pluggable service discovery
+// backend. Backend is one of "" (static Services list only), "file",
+// "mdns" or "consul".
+type DiscoveryConfig struct {
+	Backend string                `yaml:"backend"`
+	MDNS    MDNSDiscoveryConfig   `yaml:"mdns"`
+	Consul  ConsulDiscoveryConfig `yaml:"consul"`
+}
+
+// RPCDriverConfig configures a single out-of-process driver, launched as
+// a subprocess speaking the driver package's JSON-RPC contract, and
+// registered under Name so a Service can select it via its own Driver
+// field.
+type RPCDriverConfig struct {
+	Name    string   `yaml:"name"`
+	Command string   `yaml:"command"`
+	Args    []string `yaml:"args"`
+}
+
+// DriverConfig configures out-of-process drivers that are loaded and
+// registered into the driver registry at startup, before any Service can
+// reference them by name.
+type DriverConfig struct {
+	// Plugins lists paths to Go plugin (.so) files built with
+	// `go build -buildmode=plugin`, each loaded via driver.LoadPlugin.
+	// Linux and darwin only.
+	Plugins []string `yaml:"plugins"`
+	// RPC lists out-of-process drivers to launch via driver.NewRPCDriver.
+	RPC []RPCDriverConfig `yaml:"rpc"`
+}
+
+// ArbitrationConfig configures how the arbiter picks a winning service.
+// In "manual" mode (the default) activation only happens via the API.
+// In "auto" mode the arbiter continuously arbitrates based on Priority
+// and observed Active state.
+type ArbitrationConfig struct {
+	Mode string `yaml:"mode"`
+
+	// MinHoldMs is how long a newly-activated service holds the lock
+	// before it can be preempted by a higher-priority one.
+	MinHoldMs int `yaml:"min_hold_ms"`
+	// PreemptAfterMs is how long the current winner must be idle (not
+	// Active) before a lower-priority candidate can take over.
+	PreemptAfterMs int `yaml:"preempt_after_ms"`
+
+	// Sticky keeps the current winner active until it has been idle for
+	// StickyIdleCycles consecutive poll cycles, regardless of priority.
+	Sticky           bool `yaml:"sticky"`
+	StickyIdleCycles int  `yaml:"sticky_idle_cycles"`
+}
+
+// AuditConfig configures the JSON-lines audit log. Path is empty by
+// default, which disables the audit sink entirely.
+type AuditConfig struct {
+	Path       string `yaml:"path"`
+	MaxSizeMB  int    `yaml:"max_size_mb"`
+	MaxBackups int    `yaml:"max_backups"`
+	MaxAgeDays int    `yaml:"max_age_days"`
 }
 
 // Config holds the application configuration
 type Config struct {
-	APIPort         int       `yaml:"api_port"`
-	Services        []Service `yaml:"services"`
-	PollIntervalMs  int       `yaml:"poll_interval_ms"`
-	DefaultService  string    `yaml:"default_service"`
+	APIPort        int               `yaml:"api_port"`
+	Services       []Service         `yaml:"services"`
+	PollIntervalMs int               `yaml:"poll_interval_ms"`
+	DefaultService string            `yaml:"default_service"`
+	Discovery      DiscoveryConfig   `yaml:"discovery"`
+	Drivers        DriverConfig      `yaml:"drivers"`
+	Arbitration    ArbitrationConfig `yaml:"arbitration"`
+	Audit          AuditConfig       `yaml:"audit"`
+
+	// APIToken, if set, must be presented as a "Bearer <token>"
+	// Authorization header to call the /api/v1/service control endpoints.
+	// Empty disables auth on those endpoints (local development only).
+	APIToken string `yaml:"api_token"`
 }
 
 // Default returns the default configuration
@@ -28,18 +116,31 @@ func Default() *Config {
 		APIPort:        8090,
 		PollIntervalMs: 2000,
 		DefaultService: "",
+		Arbitration: ArbitrationConfig{
+			Mode:             "manual",
+			MinHoldMs:        3000,
+			PreemptAfterMs:   5000,
+			StickyIdleCycles: 3,
+		},
+		Audit: AuditConfig{
+			MaxSizeMB:  100,
+			MaxBackups: 7,
+			MaxAgeDays: 28,
+		},
 		Services: []Service{
 			{
 				Name:        "usboveri2s",
 				DisplayName: "USB Media Player",
 				BaseURL:     "http://localhost:8090",
 				Priority:    1,
+				Driver:      "usboveri2s",
 			},
 			{
 				Name:        "usbaudio",
 				DisplayName: "USB Audio Bridge",
 				BaseURL:     "http://localhost:8092",
 				Priority:    2,
+				Driver:      "usbaudio",
 			},
 		},
 	}
@@ -0,0 +1,64 @@
+// Package metrics holds the Prometheus collectors exported by i2sArbitor.
+// Collectors are registered with the default registry at init time and
+// updated directly by the arbiter as service state changes, so the
+// /metrics endpoint only needs to mount promhttp.Handler().
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	// ServiceOnline is 1 if the driver poll last succeeded, 0 otherwise.
+	ServiceOnline = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "i2sarbitor_service_online",
+		Help: "Whether the service last responded to a poll (1) or not (0).",
+	}, []string{"service"})
+
+	// ServiceLocked is 1 if the service is currently locked.
+	ServiceLocked = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "i2sarbitor_service_locked",
+		Help: "Whether the service is currently locked (1) or unlocked (0).",
+	}, []string{"service"})
+
+	// ServiceActive is 1 if the driver reports the service as active.
+	ServiceActive = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "i2sarbitor_service_active",
+		Help: "Whether the service is currently reporting activity (1) or not (0).",
+	}, []string{"service"})
+
+	// PollDuration observes how long each driver poll takes.
+	PollDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "i2sarbitor_poll_duration_seconds",
+		Help:    "Duration of driver poll calls in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"service"})
+
+	// PollErrorsTotal counts failed driver polls across all services.
+	PollErrorsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "i2sarbitor_poll_errors_total",
+		Help: "Total number of failed driver poll calls.",
+	})
+
+	// LockRequestsTotal counts lock/unlock attempts by outcome.
+	LockRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "i2sarbitor_lock_requests_total",
+		Help: "Total number of lock/unlock requests issued to drivers.",
+	}, []string{"service", "result"})
+
+	// ActivationTotal counts service activations by who triggered them.
+	ActivationTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "i2sarbitor_activation_total",
+		Help: "Total number of service activations, labelled by source (api/auto/enforce).",
+	}, []string{"service", "source"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		ServiceOnline,
+		ServiceLocked,
+		ServiceActive,
+		PollDuration,
+		PollErrorsTotal,
+		LockRequestsTotal,
+		ActivationTotal,
+	)
+}